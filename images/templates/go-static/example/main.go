@@ -5,15 +5,40 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ibshafique/base-images/images/templates/go-static/internal/logging"
+	"github.com/ibshafique/base-images/images/templates/go-static/internal/observability"
+	"github.com/ibshafique/base-images/images/templates/go-static/internal/readiness"
+	"github.com/ibshafique/base-images/images/templates/go-static/internal/shutdown"
+)
+
+// readyProbeTimeout bounds how long any single readiness probe may take.
+const readyProbeTimeout = 2 * time.Second
+
+const (
+	defaultDrainSeconds   = 5
+	defaultTimeoutSeconds = 30
 )
 
 var version = "dev" // Set via -ldflags at build time
 
+// ready gates the /ready endpoint: false until dependencies have finished
+// initializing, and flipped back to false as soon as shutdown begins so
+// Kubernetes stops routing new traffic during the drain period.
+var ready atomic.Bool
+
 func main() {
 	// Handle healthcheck command
 	if len(os.Args) > 1 && os.Args[1] == "healthcheck" {
@@ -23,48 +48,143 @@ func main() {
 		return
 	}
 
-	// Setup HTTP server
-	mux := http.NewServeMux()
-	mux.HandleFunc("/", handleRoot)
-	mux.HandleFunc("/health", handleHealth)
-	mux.HandleFunc("/ready", handleReady)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	logger := logging.New()
+	slog.SetDefault(logger)
+
+	metrics := observability.NewMetrics(version)
+	tracer, shutdownTracing, err := observability.SetupTracing(ctx, "go-static", version)
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
 	}
+	shutdown.OnShutdown(shutdown.Hook(shutdownTracing))
 
-	server := &http.Server{
-		Addr:         ":" + port,
-		Handler:      mux,
+	// Public app server.
+	appMux := http.NewServeMux()
+	appMux.HandleFunc("/", handleRoot)
+
+	appPort := os.Getenv("PORT")
+	if appPort == "" {
+		appPort = "8080"
+	}
+
+	appServer := &http.Server{
+		Addr:         ":" + appPort,
+		Handler:      logging.Middleware(logger)(metrics.Middleware(tracer, appMux)(appMux)),
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
-	// Start server
-	go func() {
-		log.Printf("Starting server v%s on :%s", version, port)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server error: %v", err)
-		}
-	}()
+	readyRegistry := readiness.NewRegistry()
+	registerReadyProbesFromEnv(readyRegistry)
+
+	// Internal admin server: health, readiness, metrics and profiling. This
+	// is never exposed outside the cluster, so it's safe to serve pprof
+	// here without auth.
+	adminMux := http.NewServeMux()
+	adminMux.HandleFunc("/health", handleHealth)
+	adminMux.Handle("/ready", handleReady(readyRegistry))
+	adminMux.Handle("/metrics", metrics.Handler())
+	adminMux.HandleFunc("/debug/pprof/", pprof.Index)
+	adminMux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	adminMux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	adminMux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	adminMux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	adminPort := os.Getenv("ADMIN_PORT")
+	if adminPort == "" {
+		adminPort = "9000"
+	}
 
-	// Graceful shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	adminServer := &http.Server{
+		Addr:         ":" + adminPort,
+		Handler:      adminMux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
 
-	log.Println("Shutting down server...")
+	// Dependencies would be initialized above this point; once they're
+	// ready, start accepting traffic.
+	ready.Store(true)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	var g errgroup.Group
+	g.Go(func() error {
+		logger.Info("starting server", "version", version, "port", appPort)
+		if err := appServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("app server error: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		logger.Info("starting admin server", "port", adminPort)
+		if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("admin server error: %w", err)
+		}
+		return nil
+	})
+
+	// Wait for a shutdown signal.
+	<-ctx.Done()
+
+	// Phase 1: drain. Mark the readiness gate false immediately so load
+	// balancers stop routing new traffic, but keep serving /health and
+	// in-flight requests while they notice.
+	logger.Info("draining connections")
+	ready.Store(false)
+	time.Sleep(envSeconds("SHUTDOWN_DRAIN_SECONDS", defaultDrainSeconds))
+
+	// Phase 2: shut the servers down.
+	logger.Info("shutting down servers")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), envSeconds("SHUTDOWN_TIMEOUT_SECONDS", defaultTimeoutSeconds))
 	defer cancel()
 
-	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+	var sg errgroup.Group
+	sg.Go(func() error { return appServer.Shutdown(shutdownCtx) })
+	sg.Go(func() error { return adminServer.Shutdown(shutdownCtx) })
+
+	failed := false
+	if err := sg.Wait(); err != nil {
+		logger.Error("servers forced to shutdown", "error", err)
+		failed = true
+	}
+
+	if err := g.Wait(); err != nil {
+		logger.Error("server error", "error", err)
+		failed = true
+	}
+
+	// Run cleanup hooks (closing a DB, flushing the tracer, ...) even on a
+	// forced/timed-out shutdown, so they aren't skipped exactly when
+	// they're most needed.
+	if err := shutdown.Run(shutdownCtx); err != nil {
+		logger.Error("error running shutdown hooks", "error", err)
+		failed = true
 	}
 
-	log.Println("Server stopped")
+	logger.Info("servers stopped")
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// envSeconds reads name as a number of seconds, falling back to
+// defaultValue if it's unset or invalid.
+func envSeconds(name string, defaultValue int) time.Duration {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return time.Duration(defaultValue) * time.Second
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return time.Duration(defaultValue) * time.Second
+	}
+	return time.Duration(n) * time.Second
 }
 
 func handleRoot(w http.ResponseWriter, r *http.Request) {
@@ -76,10 +196,37 @@ func handleHealth(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintln(w, "OK")
 }
 
-func handleReady(w http.ResponseWriter, r *http.Request) {
-	// Add readiness checks here (database, dependencies, etc.)
-	w.WriteHeader(http.StatusOK)
-	fmt.Fprintln(w, "READY")
+// handleReady wraps registry's probe handler with the startup/shutdown
+// gate: while the gate is closed, /ready fails fast without running any
+// probes.
+func handleReady(registry *readiness.Registry) http.Handler {
+	probeHandler := registry.Handler(readyProbeTimeout)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !ready.Load() {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, `{"status":"failed","checks":{}}`)
+			return
+		}
+		probeHandler(w, r)
+	})
+}
+
+// registerReadyProbesFromEnv wires built-in probes from environment
+// variables, so image users get useful readiness checks with zero code.
+// READY_HTTP_URLS is a comma-separated list of URLs to GET.
+func registerReadyProbesFromEnv(registry *readiness.Registry) {
+	urls := os.Getenv("READY_HTTP_URLS")
+	if urls == "" {
+		return
+	}
+	for _, url := range strings.Split(urls, ",") {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
+		registry.Register(url, readiness.HTTPProbe(url))
+	}
 }
 
 func healthcheck() error {
@@ -0,0 +1,56 @@
+// Package logging provides structured, JSON-by-default logging for the
+// go-static template, built on log/slog, plus request-scoped correlation
+// via context.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+type contextKey struct{}
+
+var loggerKey contextKey
+
+// New builds a slog.Logger configured from LOG_LEVEL (debug|info|warn|error,
+// default info) and LOG_FORMAT (json|text, default json).
+func New() *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(os.Getenv("LOG_LEVEL"))}
+
+	var handler slog.Handler
+	if os.Getenv("LOG_FORMAT") == "text" {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	}
+
+	return slog.New(handler)
+}
+
+func parseLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// WithLogger returns a copy of ctx carrying l, retrievable with FromContext.
+func WithLogger(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+// FromContext returns the logger stored in ctx by WithLogger, or
+// slog.Default() if none was stored.
+func FromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
@@ -0,0 +1,65 @@
+package observability
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// statusRecorder captures the status code written by the wrapped handler so
+// it can be reported to both the metrics and the tracing backends.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Middleware wraps next so every request records Prometheus counters and
+// histograms and is traced as an OpenTelemetry span. mux is used only to
+// resolve each request's registered route pattern, so requests are labeled
+// by route (e.g. "/users/") rather than by the raw, unbounded URL path —
+// labeling on raw paths lets path parameters (IDs, usernames, ...) mint an
+// unbounded number of time series.
+func (m *Metrics) Middleware(tracer trace.Tracer, mux *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			m.inFlight.Inc()
+			defer m.inFlight.Dec()
+
+			_, pattern := mux.Handler(r)
+			if pattern == "" {
+				pattern = "unmatched"
+			}
+
+			ctx, span := tracer.Start(r.Context(), r.Method+" "+pattern)
+			defer span.End()
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			start := time.Now()
+			next.ServeHTTP(rec, r.WithContext(ctx))
+			duration := time.Since(start)
+
+			status := strconv.Itoa(rec.status)
+			m.requestTotal.WithLabelValues(r.Method, pattern, status).Inc()
+			m.requestDur.WithLabelValues(r.Method, pattern).Observe(duration.Seconds())
+
+			span.SetAttributes(
+				attribute.String("http.method", r.Method),
+				attribute.String("http.route", pattern),
+				attribute.Int("http.status_code", rec.status),
+			)
+			if rec.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(rec.status))
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package observability
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ShutdownFunc stops a started subsystem and flushes any buffered data. It
+// is safe to call with a context that is already done; implementations
+// should still make a best effort to flush.
+type ShutdownFunc func(ctx context.Context) error
+
+// SetupTracing wires an OTLP/gRPC trace exporter when OTEL_EXPORTER_OTLP_ENDPOINT
+// is set, registers it as the global tracer provider, and returns a shutdown
+// func to flush and close it on exit. When the endpoint is unset, tracing is
+// a no-op: callers still get a valid tracer, it just never exports spans.
+func SetupTracing(ctx context.Context, serviceName, version string) (trace.Tracer, ShutdownFunc, error) {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		return otel.Tracer(serviceName), func(context.Context) error { return nil }, nil
+	}
+
+	// otlptracegrpc.New reads OTEL_EXPORTER_OTLP_ENDPOINT (including its
+	// scheme) and OTEL_EXPORTER_OTLP_INSECURE itself, so transport security
+	// follows the endpoint's scheme by default (TLS unless the operator
+	// opts out) instead of always being disabled here.
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(version),
+		),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Tracer(serviceName), tp.Shutdown, nil
+}
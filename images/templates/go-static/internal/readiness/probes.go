@@ -0,0 +1,50 @@
+package readiness
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// PingProbe returns a Probe that calls db.PingContext.
+func PingProbe(db *sql.DB) Probe {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// TCPProbe returns a Probe that succeeds if a TCP connection to addr can be
+// established before the context expires.
+func TCPProbe(addr string) Probe {
+	return func(ctx context.Context) error {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// HTTPProbe returns a Probe that succeeds if a GET to url returns a
+// non-5xx status before the context expires.
+func HTTPProbe(url string) Probe {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 500 {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,101 @@
+// Package readiness provides a pluggable registry of named dependency
+// probes (databases, caches, upstream services) that back the /ready
+// endpoint of the go-static template.
+package readiness
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Probe checks a single dependency and returns an error describing why it
+// is unhealthy, or nil if it's fine.
+type Probe func(ctx context.Context) error
+
+// Registry holds the named probes run on every /ready request.
+type Registry struct {
+	mu     sync.RWMutex
+	probes map[string]Probe
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{probes: make(map[string]Probe)}
+}
+
+// Register adds a named probe. Registering the same name twice overwrites
+// the previous probe.
+func (r *Registry) Register(name string, probe Probe) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.probes[name] = probe
+}
+
+// Result is the JSON body returned by Check.
+type Result struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Check runs every registered probe concurrently, bounding each one by
+// timeout, and aggregates the results. The overall status is "ok" only if
+// every probe succeeds.
+func (r *Registry) Check(ctx context.Context, timeout time.Duration) Result {
+	r.mu.RLock()
+	probes := make(map[string]Probe, len(r.probes))
+	for name, probe := range r.probes {
+		probes[name] = probe
+	}
+	r.mu.RUnlock()
+
+	type outcome struct {
+		name string
+		err  error
+	}
+	outcomes := make(chan outcome, len(probes))
+
+	for name, probe := range probes {
+		go func(name string, probe Probe) {
+			probeCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			outcomes <- outcome{name: name, err: probe(probeCtx)}
+		}(name, probe)
+	}
+
+	checks := make(map[string]string, len(probes))
+	ok := true
+	for range probes {
+		o := <-outcomes
+		if o.err != nil {
+			checks[o.name] = "failed: " + o.err.Error()
+			ok = false
+			continue
+		}
+		checks[o.name] = "ok"
+	}
+
+	status := "ok"
+	if !ok {
+		status = "failed"
+	}
+	return Result{Status: status, Checks: checks}
+}
+
+// Handler returns an http.HandlerFunc that runs Check and writes it as
+// JSON, responding 200 when every probe passed and 503 otherwise.
+func (r *Registry) Handler(timeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		result := r.Check(req.Context(), timeout)
+
+		w.Header().Set("Content-Type", "application/json")
+		if result.Status != "ok" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	}
+}
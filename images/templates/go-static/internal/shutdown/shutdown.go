@@ -0,0 +1,44 @@
+// Package shutdown lets application code built on the go-static template
+// register cleanup callbacks (closing a database, flushing a tracer, ...)
+// that run once the HTTP servers have finished draining.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Hook is a cleanup callback run during shutdown. It receives a context
+// bounded by the shutdown timeout.
+type Hook func(ctx context.Context) error
+
+var (
+	mu    sync.Mutex
+	hooks []Hook
+)
+
+// OnShutdown registers a hook to run after the HTTP servers have stopped
+// accepting connections. Hooks run in registration order.
+func OnShutdown(hook Hook) {
+	mu.Lock()
+	defer mu.Unlock()
+	hooks = append(hooks, hook)
+}
+
+// Run executes every registered hook, in order, and returns a joined error
+// of any that failed.
+func Run(ctx context.Context) error {
+	mu.Lock()
+	toRun := make([]Hook, len(hooks))
+	copy(toRun, hooks)
+	mu.Unlock()
+
+	var errs []error
+	for _, hook := range toRun {
+		if err := hook(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}